@@ -0,0 +1,109 @@
+// Package config loads the tinyfetch configuration, which controls which
+// modules are shown and how each one is rendered.
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Module is a single entry in the output, e.g. "CPU" or "Memory".
+type Module struct {
+	Name     string
+	Template string
+}
+
+// Config is the full set of modules to render, in order.
+type Config struct {
+	Modules []Module
+}
+
+// Default returns the config tinyfetch uses when no config file is found,
+// mirroring the entries tinyfetch has always shown.
+func Default() Config {
+	return Config{
+		Modules: []Module{
+			{Name: "os", Template: "{{.}}"},
+			{Name: "kernel", Template: "{{.}}"},
+			{Name: "uptime", Template: "{{.}}"},
+			{Name: "cpu", Template: "{{.Model}}"},
+			{Name: "memory", Template: "{{.Used}}M / {{.Total}}M"},
+		},
+	}
+}
+
+// Load reads a config.toml file from path. Only the subset of TOML
+// tinyfetch needs is supported: [[module]] array-of-tables with string
+// "name" and "template" keys.
+func Load(path string) (Config, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to open %v: %w", path, err)
+	}
+	defer f.Close()
+
+	var cfg Config
+	var current *Module
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if line == "[[module]]" {
+			if current != nil {
+				cfg.Modules = append(cfg.Modules, *current)
+			}
+			current = &Module{}
+			continue
+		}
+
+		if current == nil {
+			continue
+		}
+
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		k = strings.TrimSpace(k)
+		v = strings.TrimSpace(v)
+
+		s, err := unquote(v)
+		if err != nil {
+			return Config{}, fmt.Errorf("failed to parse %v: %w", line, err)
+		}
+
+		switch k {
+		case "name":
+			current.Name = s
+		case "template":
+			current.Template = s
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Config{}, fmt.Errorf("failed to scan %v: %w", path, err)
+	}
+	if current != nil {
+		cfg.Modules = append(cfg.Modules, *current)
+	}
+
+	if len(cfg.Modules) == 0 {
+		return Config{}, fmt.Errorf("%v has no [[module]] entries", path)
+	}
+
+	return cfg, nil
+}
+
+func unquote(v string) (string, error) {
+	s, err := strconv.Unquote(v)
+	if err != nil {
+		return "", fmt.Errorf("value %v is not a quoted string", v)
+	}
+	return s, nil
+}
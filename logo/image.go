@@ -0,0 +1,89 @@
+package logo
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+)
+
+// blockSize is the pixel width/height each ASCII art cell renders as.
+const blockSize = 8
+
+// Image rasterizes the logo's ASCII art into a blocky true-color
+// image: each non-space cell becomes a blockSize x blockSize square in
+// the logo's accent color, transparent elsewhere.
+func (l Logo) Image() image.Image {
+	height := len(l.Art)
+	var width int
+	for _, line := range l.Art {
+		if len(line) > width {
+			width = len(line)
+		}
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, width*blockSize, height*blockSize))
+	c := l.Accent.RGBA()
+
+	for row, line := range l.Art {
+		for col, ch := range line {
+			if ch == ' ' {
+				continue
+			}
+			fillBlock(img, col, row, c)
+		}
+	}
+
+	return img
+}
+
+func fillBlock(img *image.RGBA, col, row int, c color.RGBA) {
+	x0, y0 := col*blockSize, row*blockSize
+	for y := y0; y < y0+blockSize; y++ {
+		for x := x0; x < x0+blockSize; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+}
+
+// encodePNGBase64 encodes img as a base64-encoded PNG, the format both
+// the Kitty and iTerm2 inline image protocols expect.
+func encodePNGBase64(img image.Image) (string, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// RGBA converts the 256-color ANSI code into an approximate RGB color,
+// using the standard xterm 6x6x6 color cube (codes 16-231) and
+// grayscale ramp (232-255). Codes below 16 map to a plain gray level
+// (code*17), since tinyfetch's own logos never use them.
+func (a AccentColor) RGBA() color.RGBA {
+	i := int(a)
+
+	if i >= 232 {
+		v := uint8(8 + (i-232)*10)
+		return color.RGBA{v, v, v, 255}
+	}
+
+	if i < 16 {
+		v := uint8(i * 17)
+		return color.RGBA{v, v, v, 255}
+	}
+
+	i -= 16
+	r := cubeLevel(i / 36)
+	g := cubeLevel((i / 6) % 6)
+	b := cubeLevel(i % 6)
+	return color.RGBA{r, g, b, 255}
+}
+
+func cubeLevel(level int) uint8 {
+	if level == 0 {
+		return 0
+	}
+	return uint8(55 + level*40)
+}
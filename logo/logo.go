@@ -0,0 +1,185 @@
+// Package logo renders a distro logo next to the entries printed by
+// tinyfetch, similar to neofetch/fastfetch.
+package logo
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Logo is an ASCII art block plus the accent color used to colorize
+// entry keys next to it.
+type Logo struct {
+	Art    []string
+	Accent AccentColor
+}
+
+// AccentColor is a 256-color ANSI code.
+type AccentColor int
+
+const (
+	defaultName = "tux"
+)
+
+// logos maps a distro name (lowercase, matching /etc/os-release's ID
+// field) to its art and accent color.
+var logos = map[string]Logo{
+	"arch": {
+		Accent: 39,
+		Art: []string{
+			"      /\\      ",
+			"     /  \\     ",
+			"    /\\   \\    ",
+			"   /      \\   ",
+			"  /   ,,   \\  ",
+			" /   |  |   \\ ",
+			"/_-''    ''-_\\",
+		},
+	},
+	"debian": {
+		Accent: 196,
+		Art: []string{
+			"  _____  ",
+			" /  __ \\ ",
+			"|  /    |",
+			"|  \\___-",
+			"-_      ",
+			"  --_   ",
+		},
+	},
+	"ubuntu": {
+		Accent: 208,
+		Art: []string{
+			"         _   ",
+			"     ---(_)  ",
+			" _/  ---  \\  ",
+			"(_) |   |    ",
+			" \\  --- _/  ",
+			"     ---(_) ",
+		},
+	},
+	"fedora": {
+		Accent: 27,
+		Art: []string{
+			"      _____   ",
+			"     /   __)\\ ",
+			"     |  /  \\ \\",
+			" ____|  \\__/ /",
+			"/       \\___/ ",
+			"\\_____\\       ",
+		},
+	},
+	"nixos": {
+		Accent: 33,
+		Art: []string{
+			"  \\\\  \\\\ //  ",
+			"   \\\\  X  // ",
+			" ==  \\\\//  ==",
+			"==============",
+			" ==  //\\\\  ==",
+			"   //  X  \\\\ ",
+			"  //  // \\\\  ",
+		},
+	},
+	"alpine": {
+		Accent: 24,
+		Art: []string{
+			"   /\\ /\\   ",
+			"  /  V  \\  ",
+			" /_/   \\_\\ ",
+			"/  /   \\  \\",
+		},
+	},
+	defaultName: {
+		Accent: 255,
+		Art: []string{
+			"  .--.  ",
+			" |o_o | ",
+			" |:_/ | ",
+			"//   \\ \\",
+			"(|     | )",
+			"/'\\_   _/`\\",
+			"\\___)=(___/",
+		},
+	},
+}
+
+// Detect returns the distro name from /etc/os-release's ID field, or
+// defaultName if it cannot be determined.
+func Detect() string {
+	f, err := os.Open("/etc/os-release")
+	if err != nil {
+		return defaultName
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		k, v, ok := strings.Cut(line, "=")
+		if !ok || k != "ID" {
+			continue
+		}
+		return strings.Trim(v, `"`)
+	}
+
+	return defaultName
+}
+
+// Get returns the Logo for name, falling back to a generic logo if name
+// is not known.
+func Get(name string) Logo {
+	if l, ok := logos[strings.ToLower(name)]; ok {
+		return l
+	}
+	return logos[defaultName]
+}
+
+// Entry is a single key/value line printed next to the logo.
+type Entry struct {
+	Key   string
+	Value string
+}
+
+// Compose renders the logo to the left of entries, padding whichever
+// column (logo or entries) is shorter so both line up, and colorizes
+// each entry's key using the logo's accent color.
+func Compose(l Logo, entries []Entry) string {
+	height := len(l.Art)
+	if len(entries) > height {
+		height = len(entries)
+	}
+
+	var artWidth, keyWidth int
+	for _, line := range l.Art {
+		if len(line) > artWidth {
+			artWidth = len(line)
+		}
+	}
+	for _, e := range entries {
+		if len(e.Key) > keyWidth {
+			keyWidth = len(e.Key)
+		}
+	}
+
+	var sb strings.Builder
+	for i := 0; i < height; i++ {
+		var art string
+		if i < len(l.Art) {
+			art = l.Art[i]
+		}
+		art = art + strings.Repeat(" ", artWidth-len(art))
+
+		var key, value string
+		if i < len(entries) {
+			key = entries[i].Key + strings.Repeat(" ", keyWidth-len(entries[i].Key))
+			value = entries[i].Value
+		}
+
+		fmt.Fprintf(&sb, "\x1b[38;5;%dm%v\x1b[0m  \x1b[38;5;%dm%v\x1b[0m %v\n", l.Accent, art, l.Accent, key, value)
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
@@ -0,0 +1,73 @@
+package logo
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Protocol is a terminal graphics protocol tinyfetch can render the
+// logo through, in place of ASCII art.
+type Protocol int
+
+const (
+	ProtocolNone Protocol = iota
+	ProtocolKitty
+	ProtocolITerm2
+	ProtocolSixel
+)
+
+// DetectProtocol guesses the terminal's graphics protocol from
+// $TERM_PROGRAM and $TERM, defaulting to ProtocolNone (plain ASCII art)
+// when it can't tell.
+func DetectProtocol() Protocol {
+	if os.Getenv("TERM_PROGRAM") == "iTerm.app" {
+		return ProtocolITerm2
+	}
+
+	term := os.Getenv("TERM")
+	switch {
+	case strings.Contains(term, "kitty"):
+		return ProtocolKitty
+	case strings.Contains(term, "sixel"):
+		return ProtocolSixel
+	}
+
+	return ProtocolNone
+}
+
+// EncodeImage renders the logo as a true-color image for protocol, as
+// an inline terminal escape sequence ready to print. It returns
+// ok=false for ProtocolSixel and ProtocolNone, where the caller should
+// fall back to plain ASCII art: Sixel needs a palette-quantized encoder
+// tinyfetch doesn't have, and None means the terminal wasn't detected
+// as supporting inline images at all.
+func (l Logo) EncodeImage(protocol Protocol) (string, bool) {
+	switch protocol {
+	case ProtocolKitty:
+		b64, err := encodePNGBase64(l.Image())
+		if err != nil {
+			return "", false
+		}
+		return encodeKitty(b64), true
+	case ProtocolITerm2:
+		b64, err := encodePNGBase64(l.Image())
+		if err != nil {
+			return "", false
+		}
+		return encodeITerm2(b64), true
+	}
+	return "", false
+}
+
+// encodeKitty wraps a base64 PNG in the Kitty graphics protocol's APC
+// escape sequence, placing the image and moving on (a=T, f=100).
+func encodeKitty(b64 string) string {
+	return fmt.Sprintf("\x1b_Ga=T,f=100;%s\x1b\\", b64)
+}
+
+// encodeITerm2 wraps a base64 PNG in iTerm2's inline image OSC
+// sequence.
+func encodeITerm2(b64 string) string {
+	return fmt.Sprintf("\x1b]1337;File=inline=1;preserveAspectRatio=1:%s\a", b64)
+}
@@ -1,268 +1,285 @@
 package main
 
 import (
-	"bufio"
-	"errors"
+	"context"
+	"flag"
 	"fmt"
 	"log/slog"
 	"os"
-	"runtime"
-	"strconv"
+	"path/filepath"
 	"strings"
-	"syscall"
+	"sync"
+	"text/template"
 	"time"
-)
-
-const (
-	linux = "linux"
-)
 
-var (
-	errTargetNotSupported = errors.New("target not supported")
+	"github.com/tombuente/tinyfetch/config"
+	"github.com/tombuente/tinyfetch/logo"
+	"github.com/tombuente/tinyfetch/output"
+	"github.com/tombuente/tinyfetch/sysinfo"
 )
 
-type entry struct {
-	k string
-	v string
-}
-
 func main() {
-	target := runtime.GOOS
-	entries, err := collect(target)
+	modulesFlag := flag.String("modules", "", "comma-separated list of modules to show, e.g. os,kernel,cpu")
+	formatFlag := flag.String("format", "", "text/template string applied to every module, overriding its own template")
+	configFlag := flag.String("config", defaultConfigPath(), "path to config.toml")
+	logoFlag := flag.String("logo", "", "distro logo to render, overriding auto-detection")
+	outputFlag := flag.String("output", string(output.FormatText), `output format: "text", "json", or "prometheus"`)
+	listenFlag := flag.String("listen", ":9100", "address to listen on in prometheus mode")
+	timeoutFlag := flag.Duration("timeout", 500*time.Millisecond, "per-collector timeout")
+	flag.Parse()
+
+	cfg, err := config.Load(*configFlag)
 	if err != nil {
-		slog.Error("failed to collect", "error", err)
+		slog.Debug("falling back to default config", "error", err)
+		cfg = config.Default()
 	}
 
-	fmt.Println(entriesToString(entries))
-}
-
-func collect(target string) ([]entry, error) {
-	var entries []entry
-	osName, err := osName(target)
-	if err != nil {
-		return nil, fmt.Errorf("unable to get os: %w", err)
+	if *modulesFlag != "" {
+		cfg = filterModules(cfg, strings.Split(*modulesFlag, ","))
 	}
-	entries = append(entries, entry{k: "OS", v: osName})
-
-	kernel, err := kernel(target)
-	if err != nil {
-		return nil, fmt.Errorf("unable to get kernel: %w", err)
+	if *formatFlag != "" {
+		for i := range cfg.Modules {
+			cfg.Modules[i].Template = *formatFlag
+		}
 	}
-	entries = append(entries, entry{k: "Kernel", v: kernel})
 
-	uptime, err := uptime(target)
-	if err != nil {
-		return nil, fmt.Errorf("unable to get uptime: %w", err)
+	provider := sysinfo.New()
+
+	if output.Format(*outputFlag) == output.FormatPrometheus {
+		slog.Info("serving prometheus metrics", "listen", *listenFlag)
+		if err := output.ServePrometheus(*listenFlag, provider, *timeoutFlag); err != nil {
+			slog.Error("failed to serve prometheus metrics", "error", err)
+			os.Exit(1)
+		}
+		return
 	}
-	entries = append(entries, entry{k: "Uptime", v: uptime})
 
-	cpu, err := cpu(target)
+	entries := collect(context.Background(), provider, cfg, *timeoutFlag)
+
+	renderer, err := rendererFor(output.Format(*outputFlag), *logoFlag)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get cpu: %w", err)
+		slog.Error("failed to set up renderer", "error", err)
+		os.Exit(1)
 	}
-	entries = append(entries, entry{k: "CPU", v: cpu})
 
-	memory, err := memory(target)
+	rendered, err := renderer.Render(entries)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get memory: %w", err)
+		slog.Error("failed to render entries", "error", err)
+		os.Exit(1)
 	}
-	entries = append(entries, entry{k: "Memory", v: memory})
 
-	return entries, nil
+	fmt.Println(rendered)
 }
 
-func entriesToString(entries []entry) string {
-	var n int
-	for _, entry := range entries {
-		l := len(entry.k)
-		if n < len(entry.k) {
-			n = l
+func rendererFor(format output.Format, logoFlag string) (output.Renderer, error) {
+	switch format {
+	case output.FormatText:
+		logoName := logoFlag
+		if logoName == "" {
+			logoName = logo.Detect()
 		}
+		return output.TextRenderer{Logo: logo.Get(logoName)}, nil
+	case output.FormatJSON:
+		return output.JSONRenderer{}, nil
 	}
+	return nil, fmt.Errorf("unknown output format %q", format)
+}
 
-	var str string
-	for _, entry := range entries {
-		k := entry.k + strings.Repeat(" ", n-len(entry.k))
-		str = fmt.Sprintf("%v%v %v\n", str, k, entry.v)
+func defaultConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
 	}
-	str = strings.TrimSuffix(str, "\n")
-	return str
+	return filepath.Join(home, ".config", "tinyfetch", "config.toml")
 }
 
-func osName(target string) (string, error) {
-	switch target {
-	case linux:
-		fname := "/etc/os-release"
-		f, err := os.Open(fname)
-		if err != nil {
-			return "", fmt.Errorf("failed to open %v", fname)
-		}
-		defer f.Close()
-
-		var name string
-		scanner := bufio.NewScanner(f)
-		for scanner.Scan() {
-			line := scanner.Text()
-			if len(line) == 0 || strings.HasPrefix(line, "#") {
-				continue
-			}
-
-			parts := strings.SplitN(line, "=", 2)
-			if len(parts) != 2 {
-				continue
-			}
-
-			k := parts[0]
-			v := strings.Trim(parts[1], `"`)
+// filterModules rebuilds cfg.Modules to match names, in the order
+// given. A name already configured (e.g. via config.toml) keeps its
+// template; a recognized name with no existing config gets its default
+// template. An unrecognized name is logged and skipped rather than
+// silently producing no entry.
+func filterModules(cfg config.Config, names []string) config.Config {
+	existing := make(map[string]config.Module, len(cfg.Modules))
+	for _, m := range cfg.Modules {
+		existing[m.Name] = m
+	}
 
-			if k == "PRETTY_NAME" {
-				name = v
-				break
-			}
+	var filtered config.Config
+	for _, raw := range names {
+		name := strings.TrimSpace(raw)
+		if name == "" {
+			continue
 		}
-		if err := scanner.Err(); err != nil {
-			return "", fmt.Errorf("failed to scan %v", fname)
+
+		if m, ok := existing[name]; ok {
+			filtered.Modules = append(filtered.Modules, m)
+			continue
 		}
 
-		if name == "" {
-			return "", errors.New("no value for \"PRETTY_NAME\"")
+		tmpl, ok := defaultTemplate(name)
+		if !ok {
+			slog.Error("unknown module, skipping", "module", name)
+			continue
 		}
-		return name, nil
+		filtered.Modules = append(filtered.Modules, config.Module{Name: name, Template: tmpl})
 	}
-	return "", errTargetNotSupported
+	return filtered
 }
 
-func kernel(target string) (string, error) {
-	switch target {
-	case linux:
-		var uname syscall.Utsname
-		if err := syscall.Uname(&uname); err != nil {
-			return "", errors.New("failed syscall utsname")
-		}
-		return int8ToString(uname.Release[:]), nil
+// defaultTemplate returns the template tinyfetch uses for name when the
+// user hasn't configured one, and whether name is a recognized module.
+func defaultTemplate(name string) (string, bool) {
+	switch name {
+	case "os", "kernel", "uptime", "gpu", "host":
+		return "{{.}}", true
+	case "cpu":
+		return "{{.Model}}", true
+	case "memory":
+		return "{{.Used}}M / {{.Total}}M", true
+	case "load":
+		return "{{.One}} {{.Five}} {{.Fifteen}}", true
+	case "battery":
+		return "{{.Capacity}}% ({{.Status}})", true
+	case "disk":
+		return "{{.}}", true
 	}
-	return "", errTargetNotSupported
+	return "", false
 }
 
-func uptime(target string) (string, error) {
-	switch target {
-	case linux:
-		var sysinfo syscall.Sysinfo_t
-		if err := syscall.Sysinfo(&sysinfo); err != nil {
-			return "", errors.New("failed syscall sysinfo")
-		}
+// Collector runs a single module's collection, bounded by ctx.
+type Collector interface {
+	Name() string
+	Collect(ctx context.Context) (any, error)
+}
 
-		duration := time.Duration(sysinfo.Uptime) * time.Second
+// moduleCollector collects the value for one config.Module name.
+type moduleCollector struct {
+	name     string
+	provider sysinfo.Provider
+}
 
-		h := int(duration.Hours())
-		m := int(duration.Minutes()) % 60
+func (c moduleCollector) Name() string {
+	return c.name
+}
 
-		if h > 0 && m > 0 {
-			return fmt.Sprintf("%vh %vm", h, m), nil
-		} else if h > 0 {
-			return fmt.Sprintf("%vh", h), nil
-		}
-		return fmt.Sprintf("%vm", m), nil
+func (c moduleCollector) Collect(ctx context.Context) (any, error) {
+	type result struct {
+		v   any
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		v, err := moduleValue(c.provider, c.name)
+		done <- result{v, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.v, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
-	return "", errTargetNotSupported
 }
 
-func cpu(target string) (string, error) {
-	switch target {
-	case linux:
-		fname := "/proc/cpuinfo"
-		f, err := os.Open(fname)
-		if err != nil {
-			return "", fmt.Errorf("failed to open %v", fname)
-		}
-		defer f.Close()
-
-		var name string
-		scanner := bufio.NewScanner(f)
-		for scanner.Scan() {
-			line := scanner.Text()
-			if len(line) == 0 {
-				continue
-			}
-
-			parts := strings.SplitN(line, ":", 2)
-			if len(parts) != 2 {
-				continue
-			}
-
-			k := strings.TrimSpace(parts[0])
-			v := strings.TrimSpace(parts[1])
+// collect runs every module's Collector concurrently, each bounded by
+// timeout. A collector that errors or times out renders as "N/A"
+// instead of failing the whole run. Entries keep the order modules are
+// listed in cfg regardless of completion order.
+func collect(ctx context.Context, provider sysinfo.Provider, cfg config.Config, timeout time.Duration) []output.Entry {
+	entries := make([]output.Entry, len(cfg.Modules))
 
-			if k == "model name" {
-				name = v
-				break
-			}
-		}
-		if err := scanner.Err(); err != nil {
-			return "", fmt.Errorf("failed to scan %v", fname)
-		}
-
-		if name == "" {
-			return "", errors.New("no value for \"model name\"")
-		}
-		return name, nil
-	}
-	return "", errTargetNotSupported
-}
+	var wg sync.WaitGroup
+	for i, m := range cfg.Modules {
+		wg.Add(1)
+		go func(i int, m config.Module) {
+			defer wg.Done()
 
-func memory(target string) (string, error) {
-	switch target {
-	case linux:
-		fname := "/proc/meminfo"
-		f, err := os.Open(fname)
-		if err != nil {
-			return "", fmt.Errorf("failed to open %v", fname)
-		}
-		defer f.Close()
-
-		memInfo := make(map[string]uint64)
-		scanner := bufio.NewScanner(f)
-		for scanner.Scan() {
-			line := scanner.Text()
-			if len(line) == 0 {
-				continue
-			}
+			cctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
 
-			fields := strings.Fields(line)
-			if len(fields) <= 2 {
-				continue
+			c := moduleCollector{name: m.Name, provider: provider}
+			v, err := c.Collect(cctx)
+			if err != nil {
+				slog.Debug("collector failed", "module", c.Name(), "error", err)
+				entries[i] = output.Entry{Name: m.Name, Key: moduleLabel(m.Name), Rendered: "N/A"}
+				return
 			}
 
-			k := strings.TrimSuffix(fields[0], ":")
-			v, err := strconv.ParseUint(fields[1], 10, 64)
+			rendered, err := render(m.Template, v)
 			if err != nil {
-				return "", fmt.Errorf("cannot convert %v uint64", fields[1])
+				slog.Debug("failed to render module", "module", c.Name(), "error", err)
+				rendered = "N/A"
 			}
-			memInfo[k] = v
-		}
-		if err := scanner.Err(); err != nil {
-			return "", fmt.Errorf("failed to scan %v", fname)
-		}
 
-		totalMB := memInfo["MemTotal"] / 1024
-		freeMB := memInfo["MemFree"] / 1024
-		buffersMB := memInfo["Buffers"] / 1024
-		cachedMB := memInfo["Cached"] / 1024
+			entries[i] = output.Entry{Name: m.Name, Key: moduleLabel(m.Name), Value: v, Rendered: rendered}
+		}(i, m)
+	}
+	wg.Wait()
 
-		usedMB := totalMB - (freeMB + buffersMB + cachedMB)
+	return entries
+}
 
-		return fmt.Sprintf("%vM / %vM", usedMB, totalMB), nil
+func moduleValue(provider sysinfo.Provider, name string) (any, error) {
+	switch name {
+	case "os":
+		return provider.OS()
+	case "kernel":
+		return provider.Kernel()
+	case "uptime":
+		return provider.Uptime()
+	case "cpu":
+		return provider.CPU()
+	case "memory":
+		return provider.Memory()
+	case "load":
+		return provider.Load()
+	case "battery":
+		return provider.Battery()
+	case "gpu":
+		return provider.GPU()
+	case "disk":
+		return provider.Disk()
+	case "host":
+		return provider.Host()
 	}
-	return "", errTargetNotSupported
+	return nil, fmt.Errorf("unknown module %q", name)
 }
 
-func int8ToString(arr []int8) string {
-	b := make([]byte, len(arr))
-	for _, v := range arr {
-		if v == 0x00 {
-			break
-		}
-		b = append(b, byte(v))
+func moduleLabel(name string) string {
+	switch name {
+	case "os":
+		return "OS"
+	case "kernel":
+		return "Kernel"
+	case "uptime":
+		return "Uptime"
+	case "cpu":
+		return "CPU"
+	case "memory":
+		return "Memory"
+	case "load":
+		return "Load"
+	case "battery":
+		return "Battery"
+	case "gpu":
+		return "GPU"
+	case "disk":
+		return "Disk"
+	case "host":
+		return "Host"
+	}
+	return name
+}
+
+func render(tmpl string, data any) (string, error) {
+	t, err := template.New("module").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %q: %w", tmpl, err)
+	}
+
+	var sb strings.Builder
+	if err := t.Execute(&sb, data); err != nil {
+		return "", fmt.Errorf("failed to execute template %q: %w", tmpl, err)
 	}
-	return string(b)
+	return sb.String(), nil
 }
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tombuente/tinyfetch/config"
+	"github.com/tombuente/tinyfetch/sysinfo"
+)
+
+// fakeProvider implements sysinfo.Provider with overridable per-method
+// behavior, defaulting to an immediate zero value.
+type fakeProvider struct {
+	osFn      func() (string, error)
+	kernelFn  func() (string, error)
+	uptimeFn  func() (string, error)
+	cpuFn     func() (sysinfo.CPUInfo, error)
+	memoryFn  func() (sysinfo.MemInfo, error)
+	loadFn    func() (sysinfo.LoadAvg, error)
+	batteryFn func() (sysinfo.BatteryInfo, error)
+	gpuFn     func() (string, error)
+	diskFn    func() ([]sysinfo.DiskInfo, error)
+	hostFn    func() (string, error)
+}
+
+func (p fakeProvider) OS() (string, error) {
+	if p.osFn != nil {
+		return p.osFn()
+	}
+	return "", nil
+}
+
+func (p fakeProvider) Kernel() (string, error) {
+	if p.kernelFn != nil {
+		return p.kernelFn()
+	}
+	return "", nil
+}
+
+func (p fakeProvider) Uptime() (string, error) {
+	if p.uptimeFn != nil {
+		return p.uptimeFn()
+	}
+	return "", nil
+}
+
+func (p fakeProvider) CPU() (sysinfo.CPUInfo, error) {
+	if p.cpuFn != nil {
+		return p.cpuFn()
+	}
+	return sysinfo.CPUInfo{}, nil
+}
+
+func (p fakeProvider) Memory() (sysinfo.MemInfo, error) {
+	if p.memoryFn != nil {
+		return p.memoryFn()
+	}
+	return sysinfo.MemInfo{}, nil
+}
+
+func (p fakeProvider) Load() (sysinfo.LoadAvg, error) {
+	if p.loadFn != nil {
+		return p.loadFn()
+	}
+	return sysinfo.LoadAvg{}, nil
+}
+
+func (p fakeProvider) Battery() (sysinfo.BatteryInfo, error) {
+	if p.batteryFn != nil {
+		return p.batteryFn()
+	}
+	return sysinfo.BatteryInfo{}, nil
+}
+
+func (p fakeProvider) GPU() (string, error) {
+	if p.gpuFn != nil {
+		return p.gpuFn()
+	}
+	return "", nil
+}
+
+func (p fakeProvider) Disk() ([]sysinfo.DiskInfo, error) {
+	if p.diskFn != nil {
+		return p.diskFn()
+	}
+	return nil, nil
+}
+
+func (p fakeProvider) Host() (string, error) {
+	if p.hostFn != nil {
+		return p.hostFn()
+	}
+	return "", nil
+}
+
+func TestCollectPreservesOrderRegardlessOfCompletionOrder(t *testing.T) {
+	provider := fakeProvider{
+		osFn: func() (string, error) {
+			time.Sleep(30 * time.Millisecond)
+			return "A", nil
+		},
+		kernelFn: func() (string, error) {
+			return "B", nil
+		},
+		cpuFn: func() (sysinfo.CPUInfo, error) {
+			time.Sleep(10 * time.Millisecond)
+			return sysinfo.CPUInfo{Model: "C"}, nil
+		},
+	}
+
+	cfg := config.Config{Modules: []config.Module{
+		{Name: "os", Template: "{{.}}"},
+		{Name: "kernel", Template: "{{.}}"},
+		{Name: "cpu", Template: "{{.Model}}"},
+	}}
+
+	entries := collect(context.Background(), provider, cfg, 200*time.Millisecond)
+
+	want := []string{"A", "B", "C"}
+	if len(entries) != len(want) {
+		t.Fatalf("got %v entries, want %v", len(entries), len(want))
+	}
+	for i, w := range want {
+		if entries[i].Rendered != w {
+			t.Errorf("entries[%d].Rendered = %q, want %q", i, entries[i].Rendered, w)
+		}
+	}
+}
+
+func TestCollectDegradesOnTimeout(t *testing.T) {
+	provider := fakeProvider{
+		hostFn: func() (string, error) {
+			time.Sleep(50 * time.Millisecond)
+			return "should not appear", nil
+		},
+	}
+
+	cfg := config.Config{Modules: []config.Module{
+		{Name: "host", Template: "{{.}}"},
+	}}
+
+	entries := collect(context.Background(), provider, cfg, 5*time.Millisecond)
+
+	if len(entries) != 1 {
+		t.Fatalf("got %v entries, want 1", len(entries))
+	}
+	if entries[0].Rendered != "N/A" {
+		t.Errorf("entries[0].Rendered = %q, want %q", entries[0].Rendered, "N/A")
+	}
+}
+
+func TestCollectDegradesOnError(t *testing.T) {
+	provider := fakeProvider{
+		gpuFn: func() (string, error) {
+			return "", errors.New("no gpu")
+		},
+	}
+
+	cfg := config.Config{Modules: []config.Module{
+		{Name: "gpu", Template: "{{.}}"},
+	}}
+
+	entries := collect(context.Background(), provider, cfg, 200*time.Millisecond)
+
+	if len(entries) != 1 {
+		t.Fatalf("got %v entries, want 1", len(entries))
+	}
+	if entries[0].Rendered != "N/A" {
+		t.Errorf("entries[0].Rendered = %q, want %q", entries[0].Rendered, "N/A")
+	}
+}
@@ -0,0 +1,97 @@
+// Package output renders collected entries for different consumers: a
+// human at a terminal, a script expecting JSON, or Prometheus scraping
+// a /metrics endpoint.
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/tombuente/tinyfetch/logo"
+)
+
+// Format selects how tinyfetch's output is produced.
+type Format string
+
+const (
+	FormatText       Format = "text"
+	FormatJSON       Format = "json"
+	FormatPrometheus Format = "prometheus"
+)
+
+// Entry is a single collected module, carrying both its raw value (for
+// JSON) and its already-templated text (for the terminal renderer).
+type Entry struct {
+	Name     string // module name, e.g. "cpu"
+	Key      string // display label, e.g. "CPU"
+	Value    any    // raw value returned by the sysinfo collector
+	Rendered string // Value passed through the module's template
+}
+
+// Renderer turns entries into a final string.
+type Renderer interface {
+	Render(entries []Entry) (string, error)
+}
+
+// TextRenderer composes a logo next to each entry's templated text, the
+// historic tinyfetch output.
+type TextRenderer struct {
+	Logo logo.Logo
+}
+
+func (r TextRenderer) Render(entries []Entry) (string, error) {
+	logoEntries := make([]logo.Entry, len(entries))
+	for i, e := range entries {
+		logoEntries[i] = logo.Entry{Key: e.Key, Value: e.Rendered}
+	}
+
+	if img, ok := r.Logo.EncodeImage(logo.DetectProtocol()); ok {
+		return composeImage(img, logoEntries), nil
+	}
+	return logo.Compose(r.Logo, logoEntries), nil
+}
+
+// composeImage renders img (an inline terminal image escape sequence)
+// above the entries, for terminals that support a true-color graphics
+// protocol. Unlike logo.Compose's side-by-side ASCII layout, the image
+// occupies cells the renderer sizes on its own, so entries are listed
+// below it rather than aligned next to specific rows.
+func composeImage(img string, entries []logo.Entry) string {
+	var keyWidth int
+	for _, e := range entries {
+		if len(e.Key) > keyWidth {
+			keyWidth = len(e.Key)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(img)
+	sb.WriteString("\n")
+	for _, e := range entries {
+		fmt.Fprintf(&sb, "%-*s  %s\n", keyWidth, e.Key, e.Value)
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// JSONRenderer emits the full structured collector output as indented
+// JSON, bypassing per-module templates entirely.
+type JSONRenderer struct{}
+
+type jsonEntry struct {
+	Name  string `json:"name"`
+	Value any    `json:"value"`
+}
+
+func (JSONRenderer) Render(entries []Entry) (string, error) {
+	out := make([]jsonEntry, len(entries))
+	for i, e := range entries {
+		out[i] = jsonEntry{Name: e.Name, Value: e.Value}
+	}
+
+	b, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal entries: %w", err)
+	}
+	return string(b), nil
+}
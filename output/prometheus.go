@@ -0,0 +1,137 @@
+package output
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tombuente/tinyfetch/sysinfo"
+)
+
+// ServePrometheus starts an HTTP server on addr exposing a /metrics
+// endpoint in Prometheus text exposition format. provider is sampled
+// fresh on every scrape, with each underlying collector bounded by
+// timeout; a gauge whose collector errors or times out is omitted from
+// the response instead of failing the whole scrape. tinyfetch_info is
+// always emitted, with an empty label for each collector that failed.
+func ServePrometheus(addr string, provider sysinfo.Provider, timeout time.Duration) error {
+	http.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, prometheusMetrics(r.Context(), provider, timeout))
+	})
+
+	return http.ListenAndServe(addr, nil)
+}
+
+// sampleWithTimeout runs fn in its own goroutine and returns its result,
+// or ctx's error if fn doesn't finish within timeout.
+func sampleWithTimeout[T any](ctx context.Context, timeout time.Duration, fn func() (T, error)) (T, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		v   T
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		v, err := fn()
+		done <- result{v, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.v, r.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+func prometheusMetrics(ctx context.Context, provider sysinfo.Provider, timeout time.Duration) string {
+	var (
+		osName, kernel, uptime                      string
+		cpu                                         sysinfo.CPUInfo
+		mem                                         sysinfo.MemInfo
+		osErr, kernelErr, uptimeErr, cpuErr, memErr error
+	)
+
+	var wg sync.WaitGroup
+	wg.Add(5)
+	go func() { defer wg.Done(); osName, osErr = sampleWithTimeout(ctx, timeout, provider.OS) }()
+	go func() { defer wg.Done(); kernel, kernelErr = sampleWithTimeout(ctx, timeout, provider.Kernel) }()
+	go func() { defer wg.Done(); uptime, uptimeErr = sampleWithTimeout(ctx, timeout, provider.Uptime) }()
+	go func() { defer wg.Done(); cpu, cpuErr = sampleWithTimeout(ctx, timeout, provider.CPU) }()
+	go func() { defer wg.Done(); mem, memErr = sampleWithTimeout(ctx, timeout, provider.Memory) }()
+	wg.Wait()
+
+	var uptimeSeconds int
+	if uptimeErr != nil {
+		slog.Debug("prometheus collector failed", "metric", "uptime", "error", uptimeErr)
+	} else if uptimeSeconds, uptimeErr = parseUptimeSeconds(uptime); uptimeErr != nil {
+		slog.Debug("prometheus collector failed to parse uptime", "error", uptimeErr)
+	}
+	if cpuErr != nil {
+		slog.Debug("prometheus collector failed", "metric", "cpu", "error", cpuErr)
+	}
+	if memErr != nil {
+		slog.Debug("prometheus collector failed", "metric", "memory", "error", memErr)
+	}
+	if osErr != nil {
+		slog.Debug("prometheus collector failed", "metric", "os", "error", osErr)
+	}
+	if kernelErr != nil {
+		slog.Debug("prometheus collector failed", "metric", "kernel", "error", kernelErr)
+	}
+
+	var sb strings.Builder
+
+	if uptimeErr == nil {
+		fmt.Fprintf(&sb, "# HELP tinyfetch_uptime_seconds System uptime in seconds.\n")
+		fmt.Fprintf(&sb, "# TYPE tinyfetch_uptime_seconds gauge\n")
+		fmt.Fprintf(&sb, "tinyfetch_uptime_seconds %v\n", uptimeSeconds)
+	}
+
+	if memErr == nil {
+		fmt.Fprintf(&sb, "# HELP tinyfetch_memory_used_bytes Memory used, in bytes.\n")
+		fmt.Fprintf(&sb, "# TYPE tinyfetch_memory_used_bytes gauge\n")
+		fmt.Fprintf(&sb, "tinyfetch_memory_used_bytes %v\n", mem.Used*1024*1024)
+
+		fmt.Fprintf(&sb, "# HELP tinyfetch_memory_total_bytes Total memory, in bytes.\n")
+		fmt.Fprintf(&sb, "# TYPE tinyfetch_memory_total_bytes gauge\n")
+		fmt.Fprintf(&sb, "tinyfetch_memory_total_bytes %v\n", mem.Total*1024*1024)
+	}
+
+	if cpuErr == nil {
+		fmt.Fprintf(&sb, "# HELP tinyfetch_cpu_usage_ratio CPU usage as a ratio between 0 and 1.\n")
+		fmt.Fprintf(&sb, "# TYPE tinyfetch_cpu_usage_ratio gauge\n")
+		fmt.Fprintf(&sb, "tinyfetch_cpu_usage_ratio %v\n", cpu.UsagePercent/100)
+	}
+
+	fmt.Fprintf(&sb, "# HELP tinyfetch_info Static system information.\n")
+	fmt.Fprintf(&sb, "# TYPE tinyfetch_info gauge\n")
+	fmt.Fprintf(&sb, "tinyfetch_info{os=%q,kernel=%q,cpu_model=%q} 1\n", osName, kernel, cpu.Model)
+
+	return sb.String()
+}
+
+// parseUptimeSeconds converts the "1h 2m" / "1h" / "2m" strings
+// Provider.Uptime returns back into seconds. This loses precision below
+// a minute, matching the granularity Uptime already renders at.
+func parseUptimeSeconds(s string) (int, error) {
+	var h, m int
+
+	if n, err := fmt.Sscanf(s, "%dh %dm", &h, &m); err == nil && n == 2 {
+		return h*3600 + m*60, nil
+	}
+	if n, err := fmt.Sscanf(s, "%dh", &h); err == nil && n == 1 {
+		return h * 3600, nil
+	}
+	if n, err := fmt.Sscanf(s, "%dm", &m); err == nil && n == 1 {
+		return m * 60, nil
+	}
+	return 0, fmt.Errorf("unrecognized uptime format %q", s)
+}
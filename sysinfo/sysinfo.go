@@ -0,0 +1,71 @@
+// Package sysinfo provides a platform-independent way to query basic
+// system information such as OS name, kernel version, uptime, CPU and
+// memory usage.
+//
+// Each supported operating system ships its own implementation of
+// Provider behind a build tag. Callers should use New to obtain the
+// implementation for the platform they are running on.
+package sysinfo
+
+import "errors"
+
+// errNotImplemented is returned by Provider methods that a platform does
+// not (yet) know how to answer.
+var errNotImplemented = errors.New("not implemented")
+
+// Provider queries system information for a single platform.
+type Provider interface {
+	OS() (string, error)
+	Kernel() (string, error)
+	Uptime() (string, error)
+	CPU() (CPUInfo, error)
+	Memory() (MemInfo, error)
+	Load() (LoadAvg, error)
+	Battery() (BatteryInfo, error)
+	GPU() (string, error)
+	Disk() ([]DiskInfo, error)
+	Host() (string, error)
+}
+
+// CPUInfo describes the processor and its current load.
+type CPUInfo struct {
+	Model        string
+	Cores        int     // physical cores, or the logical count where the platform does not report them
+	Threads      int     // logical processors
+	MHz          float64 // current clock speed of the first processor
+	UsagePercent float64 // share of non-idle jiffies over a 100ms window sampled by CPU (Linux only; zero elsewhere)
+}
+
+// MemInfo describes RAM and swap usage, in megabytes.
+type MemInfo struct {
+	Total     uint64
+	Used      uint64
+	Free      uint64
+	Buffers   uint64
+	Cached    uint64
+	SwapTotal uint64
+	SwapUsed  uint64
+}
+
+// LoadAvg is the system load average over the last one, five and fifteen
+// minutes.
+type LoadAvg struct {
+	One     float64
+	Five    float64
+	Fifteen float64
+}
+
+// BatteryInfo describes the primary battery, if any.
+type BatteryInfo struct {
+	Capacity   int // percent
+	Status     string
+	Technology string
+}
+
+// DiskInfo describes a single mounted filesystem, in megabytes.
+type DiskInfo struct {
+	Mountpoint string
+	Total      uint64
+	Used       uint64
+	Free       uint64
+}
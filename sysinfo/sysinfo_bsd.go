@@ -0,0 +1,144 @@
+//go:build freebsd || openbsd
+
+package sysinfo
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// New returns the Provider for FreeBSD and OpenBSD.
+func New() Provider {
+	return bsdProvider{}
+}
+
+type bsdProvider struct{}
+
+func (bsdProvider) OS() (string, error) {
+	ostype, err := sysctlString("kern.ostype")
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%v %v", ostype, mustSysctlString("kern.osrelease")), nil
+}
+
+func (bsdProvider) Kernel() (string, error) {
+	out, err := exec.Command("uname", "-r").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run uname -r: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func (bsdProvider) Uptime() (string, error) {
+	sec, err := bootTimeSec()
+	if err != nil {
+		return "", err
+	}
+
+	duration := time.Since(time.Unix(sec, 0))
+
+	h := int(duration.Hours())
+	m := int(duration.Minutes()) % 60
+
+	if h > 0 && m > 0 {
+		return fmt.Sprintf("%vh %vm", h, m), nil
+	} else if h > 0 {
+		return fmt.Sprintf("%vh", h), nil
+	}
+	return fmt.Sprintf("%vm", m), nil
+}
+
+func (bsdProvider) CPU() (CPUInfo, error) {
+	model, err := sysctlString("hw.model")
+	if err != nil {
+		return CPUInfo{}, err
+	}
+
+	threads, err := sysctlInt("hw.ncpu")
+	if err != nil {
+		return CPUInfo{}, err
+	}
+
+	return CPUInfo{Model: model, Cores: int(threads), Threads: int(threads)}, nil
+}
+
+func (bsdProvider) Memory() (MemInfo, error) {
+	total, err := sysctlInt("hw.physmem")
+	if err != nil {
+		return MemInfo{}, err
+	}
+
+	return MemInfo{Total: uint64(total) / 1024 / 1024}, nil
+}
+
+func (bsdProvider) Load() (LoadAvg, error) {
+	return LoadAvg{}, errNotImplemented
+}
+
+func (bsdProvider) Battery() (BatteryInfo, error) {
+	return BatteryInfo{}, errNotImplemented
+}
+
+func (bsdProvider) GPU() (string, error) {
+	return "", errNotImplemented
+}
+
+func (bsdProvider) Disk() ([]DiskInfo, error) {
+	return nil, errNotImplemented
+}
+
+func (bsdProvider) Host() (string, error) {
+	return sysctlString("hw.vendor")
+}
+
+func bootTimeSec() (int64, error) {
+	out, err := exec.Command("sysctl", "-n", "kern.boottime").Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to run sysctl kern.boottime: %w", err)
+	}
+
+	fields := strings.Fields(string(out))
+	for i, f := range fields {
+		if f == "sec" && i+2 < len(fields) {
+			s := strings.TrimSuffix(fields[i+2], ",")
+			sec, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("cannot parse boottime: %w", err)
+			}
+			return sec, nil
+		}
+	}
+	return 0, fmt.Errorf("no boottime in sysctl output")
+}
+
+func sysctlString(name string) (string, error) {
+	out, err := exec.Command("sysctl", "-n", name).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run sysctl %v: %w", name, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func sysctlInt(name string) (int64, error) {
+	s, err := sysctlString(name)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("cannot parse sysctl %v: %w", name, err)
+	}
+	return v, nil
+}
+
+func mustSysctlString(name string) string {
+	v, err := sysctlString(name)
+	if err != nil {
+		return ""
+	}
+	return v
+}
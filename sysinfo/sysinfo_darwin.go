@@ -0,0 +1,173 @@
+//go:build darwin
+
+package sysinfo
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// New returns the Provider for macOS.
+func New() Provider {
+	return darwinProvider{}
+}
+
+type darwinProvider struct{}
+
+func (darwinProvider) OS() (string, error) {
+	name, err := sysctlString("kern.ostype")
+	if err != nil {
+		return "", err
+	}
+
+	out, err := exec.Command("sw_vers", "-productVersion").Output()
+	if err != nil {
+		return name, nil
+	}
+	return fmt.Sprintf("macOS %v", strings.TrimSpace(string(out))), nil
+}
+
+func (darwinProvider) Kernel() (string, error) {
+	return sysctlString("kern.osrelease")
+}
+
+func (darwinProvider) Uptime() (string, error) {
+	sec, err := bootTimeSec()
+	if err != nil {
+		return "", err
+	}
+
+	duration := time.Since(time.Unix(sec, 0))
+
+	h := int(duration.Hours())
+	m := int(duration.Minutes()) % 60
+
+	if h > 0 && m > 0 {
+		return fmt.Sprintf("%vh %vm", h, m), nil
+	} else if h > 0 {
+		return fmt.Sprintf("%vh", h), nil
+	}
+	return fmt.Sprintf("%vm", m), nil
+}
+
+func (darwinProvider) CPU() (CPUInfo, error) {
+	model, err := sysctlString("machdep.cpu.brand_string")
+	if err != nil {
+		return CPUInfo{}, err
+	}
+
+	cores, err := sysctlInt("hw.physicalcpu")
+	if err != nil {
+		return CPUInfo{}, err
+	}
+
+	threads, err := sysctlInt("hw.logicalcpu")
+	if err != nil {
+		return CPUInfo{}, err
+	}
+
+	hz, err := sysctlInt("hw.cpufrequency")
+	var mhz float64
+	if err == nil {
+		mhz = float64(hz) / 1_000_000
+	}
+
+	return CPUInfo{Model: model, Cores: int(cores), Threads: int(threads), MHz: mhz}, nil
+}
+
+func (darwinProvider) Memory() (MemInfo, error) {
+	total, err := sysctlInt("hw.memsize")
+	if err != nil {
+		return MemInfo{}, err
+	}
+
+	return MemInfo{Total: uint64(total) / 1024 / 1024}, nil
+}
+
+func (darwinProvider) Load() (LoadAvg, error) {
+	out, err := exec.Command("sysctl", "-n", "vm.loadavg").Output()
+	if err != nil {
+		return LoadAvg{}, fmt.Errorf("failed to run sysctl vm.loadavg: %w", err)
+	}
+
+	// Output looks like: { 1.23 1.45 1.67 }
+	fields := strings.Fields(strings.Trim(strings.TrimSpace(string(out)), "{}"))
+	if len(fields) < 3 {
+		return LoadAvg{}, fmt.Errorf("unexpected vm.loadavg output %q", out)
+	}
+
+	one, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return LoadAvg{}, fmt.Errorf("cannot parse load average: %w", err)
+	}
+	five, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return LoadAvg{}, fmt.Errorf("cannot parse load average: %w", err)
+	}
+	fifteen, err := strconv.ParseFloat(fields[2], 64)
+	if err != nil {
+		return LoadAvg{}, fmt.Errorf("cannot parse load average: %w", err)
+	}
+
+	return LoadAvg{One: one, Five: five, Fifteen: fifteen}, nil
+}
+
+func (darwinProvider) Battery() (BatteryInfo, error) {
+	return BatteryInfo{}, errNotImplemented
+}
+
+func (darwinProvider) GPU() (string, error) {
+	return "", errNotImplemented
+}
+
+func (darwinProvider) Disk() ([]DiskInfo, error) {
+	return nil, errNotImplemented
+}
+
+func (darwinProvider) Host() (string, error) {
+	return sysctlString("hw.model")
+}
+
+func bootTimeSec() (int64, error) {
+	out, err := exec.Command("sysctl", "-n", "kern.boottime").Output()
+	if err != nil {
+		return 0, fmt.Errorf("failed to run sysctl kern.boottime: %w", err)
+	}
+
+	// Output looks like: { sec = 1690000000, usec = 0 } Mon Jul ...
+	fields := strings.Fields(string(out))
+	for i, f := range fields {
+		if f == "sec" && i+2 < len(fields) {
+			s := strings.TrimSuffix(fields[i+2], ",")
+			sec, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("cannot parse boottime: %w", err)
+			}
+			return sec, nil
+		}
+	}
+	return 0, fmt.Errorf("no boottime in sysctl output")
+}
+
+func sysctlString(name string) (string, error) {
+	out, err := exec.Command("sysctl", "-n", name).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run sysctl %v: %w", name, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func sysctlInt(name string) (int64, error) {
+	s, err := sysctlString(name)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("cannot parse sysctl %v: %w", name, err)
+	}
+	return v, nil
+}
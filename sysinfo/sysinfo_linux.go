@@ -0,0 +1,433 @@
+//go:build linux
+
+package sysinfo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// New returns the Provider for Linux.
+func New() Provider {
+	return linuxProvider{}
+}
+
+type linuxProvider struct{}
+
+func (linuxProvider) OS() (string, error) {
+	fname := "/etc/os-release"
+	f, err := os.Open(fname)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %v", fname)
+	}
+	defer f.Close()
+
+	var name string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		k := parts[0]
+		v := strings.Trim(parts[1], `"`)
+
+		if k == "PRETTY_NAME" {
+			name = v
+			break
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to scan %v", fname)
+	}
+
+	if name == "" {
+		return "", errors.New("no value for \"PRETTY_NAME\"")
+	}
+	return name, nil
+}
+
+func (linuxProvider) Kernel() (string, error) {
+	var uname syscall.Utsname
+	if err := syscall.Uname(&uname); err != nil {
+		return "", errors.New("failed syscall utsname")
+	}
+	return int8ToString(uname.Release[:]), nil
+}
+
+func (linuxProvider) Uptime() (string, error) {
+	var sysinfo syscall.Sysinfo_t
+	if err := syscall.Sysinfo(&sysinfo); err != nil {
+		return "", errors.New("failed syscall sysinfo")
+	}
+
+	duration := time.Duration(sysinfo.Uptime) * time.Second
+
+	h := int(duration.Hours())
+	m := int(duration.Minutes()) % 60
+
+	if h > 0 && m > 0 {
+		return fmt.Sprintf("%vh %vm", h, m), nil
+	} else if h > 0 {
+		return fmt.Sprintf("%vh", h), nil
+	}
+	return fmt.Sprintf("%vm", m), nil
+}
+
+func (linuxProvider) CPU() (CPUInfo, error) {
+	fname := "/proc/cpuinfo"
+	f, err := os.Open(fname)
+	if err != nil {
+		return CPUInfo{}, fmt.Errorf("failed to open %v", fname)
+	}
+	defer f.Close()
+
+	var info CPUInfo
+	coreIDs := make(map[string]bool)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(line) == 0 {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		k := strings.TrimSpace(parts[0])
+		v := strings.TrimSpace(parts[1])
+
+		switch k {
+		case "model name":
+			if info.Model == "" {
+				info.Model = v
+			}
+		case "cpu MHz":
+			if info.MHz == 0 {
+				info.MHz, _ = strconv.ParseFloat(v, 64)
+			}
+		case "processor":
+			info.Threads++
+		case "core id":
+			coreIDs[v] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return CPUInfo{}, fmt.Errorf("failed to scan %v", fname)
+	}
+
+	if info.Model == "" {
+		return CPUInfo{}, errors.New("no value for \"model name\"")
+	}
+
+	info.Cores = len(coreIDs)
+	if info.Cores == 0 {
+		info.Cores = info.Threads
+	}
+
+	usage, err := cpuUsagePercent()
+	if err != nil {
+		return CPUInfo{}, fmt.Errorf("failed to sample cpu usage: %w", err)
+	}
+	info.UsagePercent = usage
+
+	return info, nil
+}
+
+// cpuUsagePercent samples the aggregate CPU line of /proc/stat twice,
+// 100ms apart, and returns the share of jiffies spent outside idle over
+// that delta.
+func cpuUsagePercent() (float64, error) {
+	first, err := readProcStat()
+	if err != nil {
+		return 0, err
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	second, err := readProcStat()
+	if err != nil {
+		return 0, err
+	}
+
+	totalDelta := second.total() - first.total()
+	idleDelta := second.idle - first.idle
+	if totalDelta == 0 {
+		return 0, nil
+	}
+
+	return float64(totalDelta-idleDelta) / float64(totalDelta) * 100, nil
+}
+
+type cpuJiffies struct {
+	user, nice, system, idle, iowait, irq, softirq, steal uint64
+}
+
+func (c cpuJiffies) total() uint64 {
+	return c.user + c.nice + c.system + c.idle + c.iowait + c.irq + c.softirq + c.steal
+}
+
+func readProcStat() (cpuJiffies, error) {
+	fname := "/proc/stat"
+	f, err := os.Open(fname)
+	if err != nil {
+		return cpuJiffies{}, fmt.Errorf("failed to open %v", fname)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 9 || fields[0] != "cpu" {
+			continue
+		}
+
+		values := make([]uint64, 8)
+		for i := 0; i < 8; i++ {
+			values[i], err = strconv.ParseUint(fields[i+1], 10, 64)
+			if err != nil {
+				return cpuJiffies{}, fmt.Errorf("cannot convert %v uint64", fields[i+1])
+			}
+		}
+		return cpuJiffies{
+			user: values[0], nice: values[1], system: values[2], idle: values[3],
+			iowait: values[4], irq: values[5], softirq: values[6], steal: values[7],
+		}, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return cpuJiffies{}, fmt.Errorf("failed to scan %v", fname)
+	}
+
+	return cpuJiffies{}, errors.New("no \"cpu\" line in /proc/stat")
+}
+
+func (linuxProvider) Memory() (MemInfo, error) {
+	fname := "/proc/meminfo"
+	f, err := os.Open(fname)
+	if err != nil {
+		return MemInfo{}, fmt.Errorf("failed to open %v", fname)
+	}
+	defer f.Close()
+
+	raw := make(map[string]uint64)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(line) == 0 {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) <= 2 {
+			continue
+		}
+
+		k := strings.TrimSuffix(fields[0], ":")
+		v, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return MemInfo{}, fmt.Errorf("cannot convert %v uint64", fields[1])
+		}
+		raw[k] = v
+	}
+	if err := scanner.Err(); err != nil {
+		return MemInfo{}, fmt.Errorf("failed to scan %v", fname)
+	}
+
+	totalMB := raw["MemTotal"] / 1024
+	freeMB := raw["MemFree"] / 1024
+	buffersMB := raw["Buffers"] / 1024
+	cachedMB := raw["Cached"] / 1024
+	swapTotalMB := raw["SwapTotal"] / 1024
+	swapFreeMB := raw["SwapFree"] / 1024
+
+	return MemInfo{
+		Total:     totalMB,
+		Used:      totalMB - (freeMB + buffersMB + cachedMB),
+		Free:      freeMB,
+		Buffers:   buffersMB,
+		Cached:    cachedMB,
+		SwapTotal: swapTotalMB,
+		SwapUsed:  swapTotalMB - swapFreeMB,
+	}, nil
+}
+
+func (linuxProvider) Load() (LoadAvg, error) {
+	fname := "/proc/loadavg"
+	b, err := os.ReadFile(fname)
+	if err != nil {
+		return LoadAvg{}, fmt.Errorf("failed to open %v", fname)
+	}
+
+	fields := strings.Fields(string(b))
+	if len(fields) < 3 {
+		return LoadAvg{}, fmt.Errorf("unexpected format for %v", fname)
+	}
+
+	one, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return LoadAvg{}, fmt.Errorf("cannot convert %v float64", fields[0])
+	}
+	five, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil {
+		return LoadAvg{}, fmt.Errorf("cannot convert %v float64", fields[1])
+	}
+	fifteen, err := strconv.ParseFloat(fields[2], 64)
+	if err != nil {
+		return LoadAvg{}, fmt.Errorf("cannot convert %v float64", fields[2])
+	}
+
+	return LoadAvg{One: one, Five: five, Fifteen: fifteen}, nil
+}
+
+func (linuxProvider) Battery() (BatteryInfo, error) {
+	dir, err := batteryDir()
+	if err != nil {
+		return BatteryInfo{}, err
+	}
+
+	capacity, err := readIntFile(dir + "/capacity")
+	if err != nil {
+		return BatteryInfo{}, fmt.Errorf("failed to read battery capacity: %w", err)
+	}
+
+	status, err := readStringFile(dir + "/status")
+	if err != nil {
+		return BatteryInfo{}, fmt.Errorf("failed to read battery status: %w", err)
+	}
+
+	technology, err := readStringFile(dir + "/technology")
+	if err != nil {
+		return BatteryInfo{}, fmt.Errorf("failed to read battery technology: %w", err)
+	}
+
+	return BatteryInfo{Capacity: capacity, Status: status, Technology: technology}, nil
+}
+
+// batteryDir finds the first power supply under /sys/class/power_supply
+// whose "type" is "Battery", since the battery's directory name varies
+// across hardware (BAT0, BAT1, CMB1, ...) and the same directory also
+// lists non-battery supplies like AC/ADP1.
+func batteryDir() (string, error) {
+	const base = "/sys/class/power_supply"
+
+	entries, err := os.ReadDir(base)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %v: %w", base, err)
+	}
+
+	for _, entry := range entries {
+		dir := base + "/" + entry.Name()
+
+		typ, err := readStringFile(dir + "/type")
+		if err != nil || typ != "Battery" {
+			continue
+		}
+		return dir, nil
+	}
+
+	return "", errors.New("no battery power supply found")
+}
+
+func (linuxProvider) GPU() (string, error) {
+	return "", errNotImplemented
+}
+
+func (linuxProvider) Disk() ([]DiskInfo, error) {
+	fname := "/proc/mounts"
+	f, err := os.Open(fname)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %v", fname)
+	}
+	defer f.Close()
+
+	realFS := map[string]bool{
+		"ext2": true, "ext3": true, "ext4": true, "xfs": true, "btrfs": true,
+		"vfat": true, "exfat": true, "ntfs": true, "ntfs3": true, "zfs": true, "f2fs": true,
+	}
+
+	var disks []DiskInfo
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 3 {
+			continue
+		}
+
+		mountpoint, fsType := fields[1], fields[2]
+		if !realFS[fsType] {
+			continue
+		}
+
+		var stat syscall.Statfs_t
+		if err := syscall.Statfs(mountpoint, &stat); err != nil {
+			continue
+		}
+
+		blockSize := uint64(stat.Bsize)
+		totalMB := stat.Blocks * blockSize / 1024 / 1024
+		freeMB := stat.Bfree * blockSize / 1024 / 1024
+
+		disks = append(disks, DiskInfo{
+			Mountpoint: mountpoint,
+			Total:      totalMB,
+			Free:       freeMB,
+			Used:       totalMB - freeMB,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan %v", fname)
+	}
+
+	return disks, nil
+}
+
+func (linuxProvider) Host() (string, error) {
+	fname := "/sys/class/dmi/id/product_name"
+	b, err := os.ReadFile(fname)
+	if err != nil {
+		return "", fmt.Errorf("failed to open %v", fname)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+func readIntFile(fname string) (int, error) {
+	s, err := readStringFile(fname)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(s)
+}
+
+func readStringFile(fname string) (string, error) {
+	b, err := os.ReadFile(fname)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+func int8ToString(arr []int8) string {
+	b := make([]byte, 0, len(arr))
+	for _, v := range arr {
+		if v == 0x00 {
+			break
+		}
+		b = append(b, byte(v))
+	}
+	return string(b)
+}
@@ -0,0 +1,156 @@
+//go:build windows
+
+package sysinfo
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// New returns the Provider for Windows.
+func New() Provider {
+	return windowsProvider{}
+}
+
+type windowsProvider struct{}
+
+func (windowsProvider) OS() (string, error) {
+	return wmicGet("os", "Caption")
+}
+
+func (windowsProvider) Kernel() (string, error) {
+	return wmicGet("os", "Version")
+}
+
+func (windowsProvider) Uptime() (string, error) {
+	out, err := wmicGet("os", "LastBootUpTime")
+	if err != nil {
+		return "", err
+	}
+
+	// Format: yyyymmddHHMMSS.mmmmmm+UUU
+	if len(out) < 14 {
+		return "", fmt.Errorf("unexpected LastBootUpTime format %q", out)
+	}
+	boot, err := time.Parse("20060102150405", out[:14])
+	if err != nil {
+		return "", fmt.Errorf("cannot parse LastBootUpTime: %w", err)
+	}
+
+	duration := time.Since(boot)
+
+	h := int(duration.Hours())
+	m := int(duration.Minutes()) % 60
+
+	if h > 0 && m > 0 {
+		return fmt.Sprintf("%vh %vm", h, m), nil
+	} else if h > 0 {
+		return fmt.Sprintf("%vh", h), nil
+	}
+	return fmt.Sprintf("%vm", m), nil
+}
+
+func (windowsProvider) CPU() (CPUInfo, error) {
+	model, err := wmicGet("cpu", "Name")
+	if err != nil {
+		return CPUInfo{}, err
+	}
+
+	cores, err := wmicGetInt("cpu", "NumberOfCores")
+	if err != nil {
+		return CPUInfo{}, err
+	}
+
+	threads, err := wmicGetInt("cpu", "NumberOfLogicalProcessors")
+	if err != nil {
+		return CPUInfo{}, err
+	}
+
+	mhz, err := wmicGetInt("cpu", "CurrentClockSpeed")
+	if err != nil {
+		return CPUInfo{}, err
+	}
+
+	return CPUInfo{Model: model, Cores: int(cores), Threads: int(threads), MHz: float64(mhz)}, nil
+}
+
+func (windowsProvider) Memory() (MemInfo, error) {
+	out, err := wmicGet("OS", "TotalVisibleMemorySize")
+	if err != nil {
+		return MemInfo{}, err
+	}
+
+	totalKB, err := strconv.ParseUint(out, 10, 64)
+	if err != nil {
+		return MemInfo{}, fmt.Errorf("cannot parse TotalVisibleMemorySize: %w", err)
+	}
+
+	free, err := wmicGet("OS", "FreePhysicalMemory")
+	if err != nil {
+		return MemInfo{}, err
+	}
+	freeKB, err := strconv.ParseUint(free, 10, 64)
+	if err != nil {
+		return MemInfo{}, fmt.Errorf("cannot parse FreePhysicalMemory: %w", err)
+	}
+
+	return MemInfo{Total: totalKB / 1024, Free: freeKB / 1024, Used: (totalKB - freeKB) / 1024}, nil
+}
+
+func (windowsProvider) Load() (LoadAvg, error) {
+	return LoadAvg{}, errNotImplemented
+}
+
+func (windowsProvider) Battery() (BatteryInfo, error) {
+	capacity, err := wmicGetInt("path win32_battery", "EstimatedChargeRemaining")
+	if err != nil {
+		return BatteryInfo{}, err
+	}
+
+	return BatteryInfo{Capacity: int(capacity)}, nil
+}
+
+func (windowsProvider) GPU() (string, error) {
+	return wmicGet("path win32_VideoController", "Name")
+}
+
+func (windowsProvider) Disk() ([]DiskInfo, error) {
+	return nil, errNotImplemented
+}
+
+func (windowsProvider) Host() (string, error) {
+	return wmicGet("computersystem", "Model")
+}
+
+// wmicGet queries a single property from a wmic class and returns the first
+// non-empty value in its output.
+func wmicGet(class, property string) (string, error) {
+	out, err := exec.Command("wmic", class, "get", property).Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run wmic %v get %v: %w", class, property, err)
+	}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || line == property {
+			continue
+		}
+		return line, nil
+	}
+	return "", fmt.Errorf("no value for %v", property)
+}
+
+func wmicGetInt(class, property string) (int64, error) {
+	s, err := wmicGet(class, property)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("cannot parse %v: %w", property, err)
+	}
+	return v, nil
+}